@@ -0,0 +1,13 @@
+// Package encoding provides the pluggable wire formats bin-api's echo
+// endpoints negotiate over (JSON, YAML, msgpack, protobuf, form-encoded),
+// all converging on a common map[string]interface{} model.
+package encoding
+
+// Codec converts between a Go value and its wire representation for a
+// single content type.
+type Codec interface {
+	// ContentType is the MIME type this codec produces and consumes.
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
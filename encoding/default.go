@@ -0,0 +1,13 @@
+package encoding
+
+// Default is the process-wide Registry seeded with bin-api's built-in
+// codecs. Register additional ones via Default.Register.
+var Default = NewRegistry()
+
+func init() {
+	Default.Register(JSONCodec{}, "json")
+	Default.Register(YAMLCodec{}, "yaml", "yml")
+	Default.Register(MsgpackCodec{}, "msgpack")
+	Default.Register(ProtobufCodec{}, "protobuf", "proto")
+	Default.Register(FormCodec{}, "form")
+}
@@ -0,0 +1,51 @@
+package encoding
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// FormCodec implements Codec for application/x-www-form-urlencoded.
+// Unmarshal decodes a form body into a map[string]interface{} (a key with
+// several values becomes a []string). Marshal writes each top-level value
+// with fmt's %v formatting, since form encoding has no representation for
+// nested objects or arrays; nested values are not encoded field by field.
+type FormCodec struct{}
+
+func (FormCodec) ContentType() string { return "application/x-www-form-urlencoded" }
+
+func (FormCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("form codec only supports map[string]interface{}, got %T", v)
+	}
+
+	values := url.Values{}
+	for key, val := range m {
+		values.Set(key, fmt.Sprintf("%v", val))
+	}
+	return []byte(values.Encode()), nil
+}
+
+func (FormCodec) Unmarshal(data []byte, v interface{}) error {
+	target, ok := v.(*interface{})
+	if !ok {
+		return fmt.Errorf("form codec requires a *interface{} target, got %T", v)
+	}
+
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for key, vals := range values {
+		if len(vals) == 1 {
+			result[key] = vals[0]
+		} else {
+			result[key] = vals
+		}
+	}
+	*target = result
+	return nil
+}
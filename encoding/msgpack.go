@@ -0,0 +1,16 @@
+package encoding
+
+import "github.com/vmihailenco/msgpack/v5"
+
+// MsgpackCodec implements Codec for application/msgpack.
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) ContentType() string { return "application/msgpack" }
+
+func (MsgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+func (MsgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.Unmarshal(data, v)
+}
@@ -0,0 +1,79 @@
+package encoding
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ProtobufCodec implements Codec for application/protobuf. bin-api has no
+// fixed .proto schema for the echo model, so it represents the
+// map[string]interface{} model as a google.protobuf.Struct, which can hold
+// arbitrary JSON-like data.
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) ContentType() string { return "application/protobuf" }
+
+func (ProtobufCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("protobuf codec only supports map[string]interface{}, got %T", v)
+	}
+
+	s, err := structpb.NewStruct(normalizeStructValue(m).(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(s)
+}
+
+// normalizeStructValue coerces types that structpb.NewStruct otherwise
+// rejects (the []string HandleRequest uses for multi-valued headers/query
+// params, and the []map[string]interface{} parseMultipart uses for
+// multi-file form fields) into the []interface{}/map[string]interface{}
+// shapes it accepts, recursing into nested maps and slices.
+func normalizeStructValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = normalizeStructValue(val)
+		}
+		return out
+	case []string:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = val
+		}
+		return out
+	case []map[string]interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = normalizeStructValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = normalizeStructValue(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (ProtobufCodec) Unmarshal(data []byte, v interface{}) error {
+	target, ok := v.(*interface{})
+	if !ok {
+		return fmt.Errorf("protobuf codec requires a *interface{} target, got %T", v)
+	}
+
+	var s structpb.Struct
+	if err := proto.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*target = s.AsMap()
+	return nil
+}
@@ -0,0 +1,68 @@
+package encoding
+
+import (
+	"strings"
+	"sync"
+)
+
+// Registry looks up Codecs by content type or by the short format name used
+// in a "?format=" query override (e.g. "json", "yaml").
+type Registry struct {
+	mu       sync.RWMutex
+	byType   map[string]Codec
+	byFormat map[string]Codec
+}
+
+// NewRegistry builds an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		byType:   make(map[string]Codec),
+		byFormat: make(map[string]Codec),
+	}
+}
+
+// Register adds codec under its own content type and under any number of
+// short format names.
+func (r *Registry) Register(codec Codec, formats ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byType[codec.ContentType()] = codec
+	for _, format := range formats {
+		r.byFormat[strings.ToLower(format)] = codec
+	}
+}
+
+// ByContentType returns the codec registered for contentType (its "; ..."
+// parameters, if any, are ignored), or nil if none matches.
+func (r *Registry) ByContentType(contentType string) Codec {
+	base, _, _ := strings.Cut(contentType, ";")
+	base = strings.TrimSpace(base)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byType[base]
+}
+
+// ByFormat returns the codec registered under a short format name (the
+// "?format=" query override), or nil if none matches.
+func (r *Registry) ByFormat(format string) Codec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byFormat[strings.ToLower(format)]
+}
+
+// Negotiate picks a codec for an Accept header's comma-separated list of
+// media ranges, returning def if none of them match a registered codec.
+func (r *Registry) Negotiate(accept string, def Codec) Codec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, candidate := range strings.Split(accept, ",") {
+		base, _, _ := strings.Cut(strings.TrimSpace(candidate), ";")
+		if codec, ok := r.byType[base]; ok {
+			return codec
+		}
+	}
+	return def
+}
@@ -0,0 +1,16 @@
+package encoding
+
+import "gopkg.in/yaml.v3"
+
+// YAMLCodec implements Codec for application/x-yaml.
+type YAMLCodec struct{}
+
+func (YAMLCodec) ContentType() string { return "application/x-yaml" }
+
+func (YAMLCodec) Marshal(v interface{}) ([]byte, error) {
+	return yaml.Marshal(v)
+}
+
+func (YAMLCodec) Unmarshal(data []byte, v interface{}) error {
+	return yaml.Unmarshal(data, v)
+}
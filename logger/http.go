@@ -1,8 +1,11 @@
 package logger
 
 import (
+	"bufio"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"strconv"
@@ -10,6 +13,8 @@ import (
 
 	"github.com/getsentry/sentry-go"
 	sentryhttp "github.com/getsentry/sentry-go/http"
+
+	"github.com/secnex/bin-api/metrics"
 )
 
 // SentryConfig holds Sentry configuration
@@ -133,17 +138,41 @@ func LogHTTPRequest(next http.Handler) http.Handler {
 	return sentryHandler.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
+		metrics.Default().InFlightInc()
+		defer metrics.Default().InFlightDec()
+
+		// Request-ID: reuse an inbound X-Request-ID, or mint one, and echo
+		// it back so callers can correlate logs/Sentry events/the response.
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			if id, err := newRequestID(); err == nil {
+				requestID = id
+			}
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+		r = r.WithContext(WithRequestID(r.Context(), requestID))
+
+		// Continue a W3C traceparent from an upstream gateway, if present.
+		r = applyTraceParent(r)
+
 		// Get Sentry hub from context
 		hub := sentry.GetHubFromContext(r.Context())
 		if hub == nil {
 			hub = sentry.CurrentHub()
 		}
 
-		// Start a transaction for performance monitoring
-		transaction := sentry.StartTransaction(r.Context(), fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		// Start a transaction for performance monitoring, continuing the
+		// caller's trace (from traceparent, translated above, or a native
+		// sentry-trace header) when one is present.
+		transaction := sentry.StartTransaction(r.Context(), fmt.Sprintf("%s %s", r.Method, r.URL.Path),
+			sentry.ContinueFromRequest(r))
 		transaction.SetTag("http.method", r.Method)
 		transaction.SetTag("http.url", r.URL.String())
 		transaction.SetTag("user_agent", r.UserAgent())
+		transaction.SetTag("request_id", requestID)
+		if ts := traceState(r); ts != "" {
+			transaction.SetTag("tracestate", ts)
+		}
 		defer transaction.Finish()
 
 		// Update request context with transaction
@@ -262,177 +291,99 @@ func LogHTTPRequest(next http.Handler) http.Handler {
 			})
 		}
 
-		// Log ausgeben
-		log.Println(FormatHTTPLog(entry))
+		metrics.Default().Observe(entry.Method, entry.Path, entry.StatusCode, entry.ResponseTime, entry.ResponseSize)
+
+		// Log-Eintrag als strukturierten Record ausgeben. Sentry forwarding is
+		// suppressed here (skip_sentry) because the status-based blocks above
+		// already reported this exact request to Sentry as performance data, a
+		// client-error warning or a server error; forwarding this record too
+		// would double events.
+		Default().LogAttrs(r.Context(), slog.LevelInfo, "http_request",
+			slog.String("request_id", requestID),
+			slog.String("trace_id", transaction.TraceID.String()),
+			slog.String("http.method", entry.Method),
+			slog.String("http.path", entry.Path),
+			slog.Int("http.status_code", entry.StatusCode),
+			slog.Int64("duration_ms", entry.ResponseTime.Milliseconds()),
+			slog.Int64("response_size", entry.ResponseSize),
+			slog.String("remote_addr", entry.RemoteAddr),
+			slog.String("user_agent", entry.UserAgent),
+			slog.String("host", entry.Host),
+			slog.Any("error", entry.Error),
+			slog.Bool("skip_sentry", true),
+		)
 	}))
 }
 
-// LogError logs an error to both standard logger and Sentry
-func LogError(err error, message string, tags map[string]string, extra map[string]interface{}) {
-	log.Printf("ERROR: %s: %v", message, err)
-
-	sentry.WithScope(func(scope *sentry.Scope) {
-		scope.SetLevel(sentry.LevelError)
-
-		for key, value := range tags {
-			scope.SetTag(key, value)
-		}
-
-		if extra != nil {
-			scope.SetContext("extra", extra)
-		}
-
-		if message != "" {
-			scope.SetTag("message", message)
-		}
+// attrsFromMaps flattens tags and extra into slog attributes. Sentry
+// forwarding (when enabled) happens automatically via the SentryHandler
+// installed in the default logger's handler chain.
+func attrsFromMaps(tags map[string]string, extra map[string]interface{}) []any {
+	attrs := make([]any, 0, len(tags)+len(extra))
+	for key, value := range tags {
+		attrs = append(attrs, slog.String(key, value))
+	}
+	for key, value := range extra {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+	return attrs
+}
 
-		sentry.CaptureException(err)
-	})
+// LogError logs an error as a structured record; the Sentry handler, if
+// configured, forwards it as an exception.
+func LogError(err error, message string, tags map[string]string, extra map[string]interface{}) {
+	attrs := append(attrsFromMaps(tags, extra), slog.Any("error", err))
+	Default().Error(message, attrs...)
 }
 
-// LogWarning logs a warning to both standard logger and Sentry
+// LogWarning logs a warning as a structured record.
 func LogWarning(message string, tags map[string]string, extra map[string]interface{}) {
-	log.Printf("WARNING: %s", message)
-
-	sentry.WithScope(func(scope *sentry.Scope) {
-		scope.SetLevel(sentry.LevelWarning)
-
-		for key, value := range tags {
-			scope.SetTag(key, value)
-		}
-
-		if extra != nil {
-			scope.SetContext("extra", extra)
-		}
-
-		sentry.CaptureMessage(message)
-	})
+	Default().Warn(message, attrsFromMaps(tags, extra)...)
 }
 
-// LogInfo logs an info message to both standard logger and Sentry for performance monitoring
+// LogInfo logs an info message as a structured record.
 func LogInfo(message string, tags map[string]string, extra map[string]interface{}) {
-	log.Printf("INFO: %s", message)
-
-	sentry.WithScope(func(scope *sentry.Scope) {
-		scope.SetLevel(sentry.LevelInfo)
-
-		for key, value := range tags {
-			scope.SetTag(key, value)
-		}
-
-		if extra != nil {
-			scope.SetContext("extra", extra)
-		}
-
-		scope.SetTag("log_type", "performance")
-		sentry.CaptureMessage(message)
-	})
+	Default().Info(message, attrsFromMaps(tags, extra)...)
 }
 
-// LogPerformance logs performance metrics to Sentry
+// LogPerformance logs an operation's duration as a structured record.
 func LogPerformance(operation string, duration time.Duration, tags map[string]string, extra map[string]interface{}) {
-	message := fmt.Sprintf("Performance: %s took %v", operation, duration)
-	log.Printf("PERFORMANCE: %s", message)
-
-	sentry.WithScope(func(scope *sentry.Scope) {
-		scope.SetLevel(sentry.LevelInfo)
-		scope.SetTag("log_type", "performance")
-		scope.SetTag("operation", operation)
-		scope.SetTag("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
-
-		for key, value := range tags {
-			scope.SetTag(key, value)
-		}
-
-		if extra == nil {
-			extra = make(map[string]interface{})
-		}
-		extra["duration_ns"] = duration.Nanoseconds()
-		extra["duration_ms"] = duration.Seconds() * 1000
-		scope.SetContext("performance", extra)
-
-		sentry.CaptureMessage(message)
-	})
+	attrs := append(attrsFromMaps(tags, extra),
+		slog.String("operation", operation),
+		slog.Int64("duration_ms", duration.Milliseconds()),
+	)
+	Default().Info("performance", attrs...)
 }
 
-// LogMetric logs custom metrics to Sentry for performance monitoring
+// LogMetric logs a named metric as a structured record.
 func LogMetric(name string, value interface{}, unit string, tags map[string]string) {
-	message := fmt.Sprintf("Metric: %s = %v %s", name, value, unit)
-	log.Printf("METRIC: %s", message)
-
-	sentry.WithScope(func(scope *sentry.Scope) {
-		scope.SetLevel(sentry.LevelInfo)
-		scope.SetTag("log_type", "metric")
-		scope.SetTag("metric_name", name)
-		scope.SetTag("metric_unit", unit)
-
-		for key, val := range tags {
-			scope.SetTag(key, val)
-		}
-
-		scope.SetContext("metric", map[string]interface{}{
-			"name":  name,
-			"value": value,
-			"unit":  unit,
-		})
-
-		sentry.CaptureMessage(message)
-	})
+	attrs := append(attrsFromMaps(tags, nil),
+		slog.String("metric_name", name),
+		slog.Any("metric_value", value),
+		slog.String("metric_unit", unit),
+	)
+	Default().Info("metric", attrs...)
 }
 
-// LogDatabaseQuery logs database performance to Sentry
+// LogDatabaseQuery logs a database query's duration as a structured record.
 func LogDatabaseQuery(query string, duration time.Duration, rowsAffected int64, tags map[string]string) {
-	message := fmt.Sprintf("DB Query took %v, affected %d rows", duration, rowsAffected)
-	log.Printf("DB_PERFORMANCE: %s", message)
-
-	sentry.WithScope(func(scope *sentry.Scope) {
-		scope.SetLevel(sentry.LevelInfo)
-		scope.SetTag("log_type", "database_performance")
-		scope.SetTag("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
-		scope.SetTag("rows_affected", fmt.Sprintf("%d", rowsAffected))
-
-		for key, value := range tags {
-			scope.SetTag(key, value)
-		}
-
-		scope.SetContext("database", map[string]interface{}{
-			"query":         query,
-			"duration_ns":   duration.Nanoseconds(),
-			"duration_ms":   duration.Seconds() * 1000,
-			"rows_affected": rowsAffected,
-		})
-
-		sentry.CaptureMessage(message)
-	})
+	attrs := append(attrsFromMaps(tags, nil),
+		slog.String("query", query),
+		slog.Int64("duration_ms", duration.Milliseconds()),
+		slog.Int64("rows_affected", rowsAffected),
+	)
+	Default().Info("database_query", attrs...)
 }
 
-// LogAPICall logs external API call performance to Sentry
+// LogAPICall logs an external API call's outcome as a structured record.
 func LogAPICall(endpoint string, method string, statusCode int, duration time.Duration, tags map[string]string) {
-	message := fmt.Sprintf("API Call: %s %s returned %d in %v", method, endpoint, statusCode, duration)
-	log.Printf("API_PERFORMANCE: %s", message)
-
-	sentry.WithScope(func(scope *sentry.Scope) {
-		scope.SetLevel(sentry.LevelInfo)
-		scope.SetTag("log_type", "api_performance")
-		scope.SetTag("api_endpoint", endpoint)
-		scope.SetTag("api_method", method)
-		scope.SetTag("api_status_code", fmt.Sprintf("%d", statusCode))
-		scope.SetTag("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
-
-		for key, value := range tags {
-			scope.SetTag(key, value)
-		}
-
-		scope.SetContext("api_call", map[string]interface{}{
-			"endpoint":    endpoint,
-			"method":      method,
-			"status_code": statusCode,
-			"duration_ns": duration.Nanoseconds(),
-			"duration_ms": duration.Seconds() * 1000,
-		})
-
-		sentry.CaptureMessage(message)
-	})
+	attrs := append(attrsFromMaps(tags, nil),
+		slog.String("api_endpoint", endpoint),
+		slog.String("api_method", method),
+		slog.Int("api_status_code", statusCode),
+		slog.Int64("duration_ms", duration.Milliseconds()),
+	)
+	Default().Info("api_call", attrs...)
 }
 
 // Flush flushes any pending Sentry events
@@ -459,3 +410,21 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	rw.size += int64(size)
 	return size, err
 }
+
+// Flush leitet an http.Flusher weiter, falls der zugrunde liegende Writer das unterstützt,
+// damit Streaming-Handler hinter dem Logger tatsächlich auf den Socket flushen.
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack leitet an http.Hijacker weiter, falls der zugrunde liegende Writer das unterstützt,
+// damit z.B. der h2c-Upgrade-Pfad die Verbindung übernehmen kann.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("underlying ResponseWriter does not support hijacking")
+	}
+	return h.Hijack()
+}
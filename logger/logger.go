@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Logger is the structured logger used across bin-api. It wraps *slog.Logger
+// so HTTP, database and API call sites can attach request-scoped fields
+// without importing log/slog directly.
+type Logger struct {
+	*slog.Logger
+}
+
+var defaultLogger = NewLoggerFromEnv()
+
+// NewLogger builds a Logger around the given slog.Handler.
+func NewLogger(handler slog.Handler) *Logger {
+	return &Logger{Logger: slog.New(handler)}
+}
+
+// NewLoggerFromEnv builds a Logger using LOG_FORMAT and LOG_LEVEL, forwarding
+// to Sentry as well when SENTRY_DSN is set.
+func NewLoggerFromEnv() *Logger {
+	level := levelFromEnv()
+	handlers := []slog.Handler{handlerFromEnv(level)}
+
+	if sentryHandler := NewSentryHandlerFromEnv(level); sentryHandler != nil {
+		handlers = append(handlers, sentryHandler)
+	}
+
+	return NewLogger(NewMultiHandler(handlers...))
+}
+
+// Default returns the process-wide Logger used by the LogXxx helpers.
+func Default() *Logger {
+	return defaultLogger
+}
+
+// SetDefault replaces the process-wide Logger, e.g. in tests.
+func SetDefault(l *Logger) {
+	defaultLogger = l
+}
+
+func levelFromEnv() slog.Level {
+	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func handlerFromEnv(level slog.Level) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if strings.ToLower(os.Getenv("LOG_FORMAT")) == "json" {
+		return slog.NewJSONHandler(os.Stdout, opts)
+	}
+	return slog.NewTextHandler(os.Stdout, opts)
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// SentryHandler is a slog.Handler that forwards log records to the active
+// Sentry hub, mapping slog levels onto Sentry levels and attaching record
+// attributes as extra context.
+type SentryHandler struct {
+	level slog.Leveler
+	attrs []slog.Attr
+}
+
+// skipSentryAttr is a reserved record attribute that callers can set to
+// true (via slog.Bool(skipSentryAttr, true)) to suppress Sentry forwarding
+// for a record that has already been reported to Sentry through a more
+// specific path, e.g. LogHTTPRequest's per-request performance/error
+// captures.
+const skipSentryAttr = "skip_sentry"
+
+// NewSentryHandler builds a SentryHandler that forwards records at or above
+// level to Sentry.
+func NewSentryHandler(level slog.Leveler) *SentryHandler {
+	return &SentryHandler{level: level}
+}
+
+// NewSentryHandlerFromEnv returns a SentryHandler if SENTRY_DSN is set, or
+// nil if Sentry forwarding should be disabled.
+func NewSentryHandlerFromEnv(level slog.Leveler) *SentryHandler {
+	if os.Getenv("SENTRY_DSN") == "" {
+		return nil
+	}
+	return NewSentryHandler(level)
+}
+
+func (h *SentryHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *SentryHandler) Handle(ctx context.Context, record slog.Record) error {
+	skip := false
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == skipSentryAttr && attr.Value.Kind() == slog.KindBool && attr.Value.Bool() {
+			skip = true
+			return false
+		}
+		return true
+	})
+	if skip {
+		return nil
+	}
+
+	hub := sentry.GetHubFromContext(ctx)
+	if hub == nil {
+		hub = sentry.CurrentHub()
+	}
+
+	extra := make(map[string]interface{}, record.NumAttrs()+len(h.attrs))
+	for _, attr := range h.attrs {
+		extra[attr.Key] = attr.Value.Any()
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		extra[attr.Key] = attr.Value.Any()
+		return true
+	})
+
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetLevel(sentryLevel(record.Level))
+		scope.SetContext("log", extra)
+
+		if err, ok := extra["error"].(error); ok {
+			hub.CaptureException(err)
+			return
+		}
+		hub.CaptureMessage(record.Message)
+	})
+
+	return nil
+}
+
+func (h *SentryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &next
+}
+
+func (h *SentryHandler) WithGroup(_ string) slog.Handler {
+	// Sentry context is flat, so groups are ignored rather than nested.
+	return h
+}
+
+// sentryLevel maps a slog level onto the closest Sentry severity level.
+func sentryLevel(level slog.Level) sentry.Level {
+	switch {
+	case level >= slog.LevelError:
+		return sentry.LevelError
+	case level >= slog.LevelWarn:
+		return sentry.LevelWarning
+	case level >= slog.LevelInfo:
+		return sentry.LevelInfo
+	default:
+		return sentry.LevelDebug
+	}
+}
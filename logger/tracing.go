@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// W3C Trace Context headers (https://www.w3.org/TR/trace-context/), as sent
+// by a gateway/proxy in front of bin-api.
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+	sentryTraceHeader = "sentry-trace"
+)
+
+// applyTraceParent translates an incoming W3C traceparent header into the
+// Sentry SDK's own sentry-trace header ("trace_id-span_id-sampled"), so a
+// subsequent sentry.ContinueFromRequest continues the same distributed
+// trace. It is a no-op if the request has no traceparent, the traceparent
+// is malformed, or a sentry-trace header is already present.
+func applyTraceParent(r *http.Request) *http.Request {
+	if r.Header.Get(sentryTraceHeader) != "" {
+		return r
+	}
+
+	parts := strings.Split(r.Header.Get(traceparentHeader), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return r
+	}
+	traceID, parentID := parts[1], parts[2]
+
+	sampled := "0"
+	if flags, err := strconv.ParseUint(parts[3], 16, 8); err == nil && flags&0x01 == 1 {
+		sampled = "1"
+	}
+
+	r.Header.Set(sentryTraceHeader, fmt.Sprintf("%s-%s-%s", traceID, parentID, sampled))
+	return r
+}
+
+// traceState returns the raw tracestate header, if any, for attaching to
+// the Sentry transaction as a tag.
+func traceState(r *http.Request) string {
+	return r.Header.Get(tracestateHeader)
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PathNormalizer collapses a request path into a low-cardinality label, e.g.
+// "/users/42" -> "/users/{id}". Without one, every distinct path value (IDs,
+// UUIDs, ...) becomes its own histogram/counter series. method is passed
+// alongside so a router-backed normalizer can resolve the right route.
+type PathNormalizer func(method, path string) string
+
+// identityNormalizer is used until a caller registers its own via
+// SetNormalizer or Options.Normalize.
+func identityNormalizer(_, path string) string { return path }
+
+// Options configures a Registry.
+type Options struct {
+	// Normalize collapses high-cardinality path segments before they become
+	// a Prometheus label. Defaults to the identity function.
+	Normalize PathNormalizer
+	// DurationBuckets overrides the request duration histogram buckets.
+	// Defaults to prometheus.DefBuckets.
+	DurationBuckets []float64
+}
+
+// Registry wraps the Prometheus collectors bin-api exposes on /metrics.
+type Registry struct {
+	registry  *prometheus.Registry
+	normalize PathNormalizer
+
+	requestsTotal    *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	responseSize     *prometheus.HistogramVec
+	requestsInFlight prometheus.Gauge
+}
+
+// NewRegistry builds a Registry with its own prometheus.Registry, so bin-api
+// never leaks instrumentation into the global default registry.
+func NewRegistry(opts Options) *Registry {
+	if opts.Normalize == nil {
+		opts.Normalize = identityNormalizer
+	}
+	if opts.DurationBuckets == nil {
+		opts.DurationBuckets = prometheus.DefBuckets
+	}
+
+	r := &Registry{
+		registry:  prometheus.NewRegistry(),
+		normalize: opts.Normalize,
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests processed, labeled by method, path and status.",
+		}, []string{"method", "path", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, labeled by method, path and status.",
+			Buckets: opts.DurationBuckets,
+		}, []string{"method", "path", "status"}),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_response_size_bytes",
+			Help:    "HTTP response size in bytes, labeled by method, path and status.",
+			Buckets: prometheus.ExponentialBuckets(100, 10, 6),
+		}, []string{"method", "path", "status"}),
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served.",
+		}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.requestsTotal,
+		r.requestDuration,
+		r.responseSize,
+		r.requestsInFlight,
+	)
+
+	return r
+}
+
+// SetNormalizer replaces the path normalizer used for future observations,
+// e.g. so the server's router can collapse "/users/{id}" itself.
+func (r *Registry) SetNormalizer(normalize PathNormalizer) {
+	if normalize == nil {
+		normalize = identityNormalizer
+	}
+	r.normalize = normalize
+}
+
+// Observe records one completed HTTP request.
+func (r *Registry) Observe(method, path string, status int, duration time.Duration, responseSize int64) {
+	labels := prometheus.Labels{
+		"method": method,
+		"path":   r.normalize(method, path),
+		"status": strconv.Itoa(status),
+	}
+	r.requestsTotal.With(labels).Inc()
+	r.requestDuration.With(labels).Observe(duration.Seconds())
+	r.responseSize.With(labels).Observe(float64(responseSize))
+}
+
+// InFlightInc marks the start of a request being served.
+func (r *Registry) InFlightInc() {
+	r.requestsInFlight.Inc()
+}
+
+// InFlightDec marks the end of a request being served.
+func (r *Registry) InFlightDec() {
+	r.requestsInFlight.Dec()
+}
+
+// Handler returns the HTTP handler to mount at /metrics.
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+var defaultRegistry = NewRegistry(Options{})
+
+// Default returns the process-wide Registry used by logger.LogHTTPRequest.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// SetNormalizer replaces the path normalizer on the default Registry.
+func SetNormalizer(normalize PathNormalizer) {
+	defaultRegistry.SetNormalizer(normalize)
+}
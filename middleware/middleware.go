@@ -0,0 +1,19 @@
+// Package middleware provides composable http.Handler wrappers (rate
+// limiting, basic auth, CORS, compression, ...) that Server.Use and
+// Server.Start chain in front of the router.
+package middleware
+
+import "net/http"
+
+// Middleware wraps an http.Handler to add cross-cutting behavior.
+type Middleware func(http.Handler) http.Handler
+
+// Chain applies middlewares around handler in order, so the first
+// middleware in the list is the outermost wrapper (the first to see a
+// request and the last to see its response).
+func Chain(handler http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
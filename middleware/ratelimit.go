@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// perIPIdleTimeout bounds how long an IP's limiter is kept after its last
+// request, so perIPLimiters doesn't grow without bound over a long-running
+// server's lifetime.
+const perIPIdleTimeout = 10 * time.Minute
+
+// RateLimit returns a Middleware enforcing a token-bucket limit of
+// eventsPerSecond (with the given burst), shared globally across all
+// callers.
+func RateLimit(eventsPerSecond float64, burst int) Middleware {
+	limiter := rate.NewLimiter(rate.Limit(eventsPerSecond), burst)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !limiter.Allow() {
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// PerIPRateLimit returns a Middleware enforcing a token-bucket limit of
+// eventsPerSecond (with the given burst) tracked independently per client
+// IP.
+func PerIPRateLimit(eventsPerSecond float64, burst int) Middleware {
+	limiters := &perIPLimiters{
+		limiters:        make(map[string]*perIPLimiterEntry),
+		eventsPerSecond: rate.Limit(eventsPerSecond),
+		burst:           burst,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := r.RemoteAddr
+			if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+				ip = host
+			}
+
+			if !limiters.forIP(ip).Allow() {
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// perIPLimiters lazily creates and caches one rate.Limiter per client IP,
+// evicting IPs idle for longer than perIPIdleTimeout.
+type perIPLimiters struct {
+	mu              sync.Mutex
+	limiters        map[string]*perIPLimiterEntry
+	eventsPerSecond rate.Limit
+	burst           int
+}
+
+type perIPLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func (p *perIPLimiters) forIP(ip string) *rate.Limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	p.evictLocked(now)
+
+	entry, ok := p.limiters[ip]
+	if !ok {
+		entry = &perIPLimiterEntry{limiter: rate.NewLimiter(p.eventsPerSecond, p.burst)}
+		p.limiters[ip] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter
+}
+
+// evictLocked drops limiters that haven't been used in over
+// perIPIdleTimeout. Called with p.mu held.
+func (p *perIPLimiters) evictLocked(now time.Time) {
+	for ip, entry := range p.limiters {
+		if now.Sub(entry.lastSeen) > perIPIdleTimeout {
+			delete(p.limiters, ip)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/secnex/bin-api/encoding"
+)
+
+// decodeBody parses r's body into a generic model according to its
+// Content-Type: multipart/form-data and url-encoded forms are parsed via
+// net/http's own form support, everything else goes through the matching
+// codec in encoding.Default (falling back to JSON for an unrecognized or
+// missing Content-Type).
+func decodeBody(r *http.Request) (interface{}, error) {
+	if r.Body == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	contentType, _, _ := strings.Cut(r.Header.Get("Content-Type"), ";")
+	contentType = strings.TrimSpace(contentType)
+
+	switch contentType {
+	case "multipart/form-data":
+		return parseMultipart(r)
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		form := make(map[string]interface{}, len(r.PostForm))
+		for key, values := range r.PostForm {
+			if len(values) == 1 {
+				form[key] = values[0]
+			} else {
+				form[key] = values
+			}
+		}
+		return form, nil
+	}
+
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	codec := encoding.Default.ByContentType(contentType)
+	if codec == nil {
+		codec = encoding.JSONCodec{}
+	}
+
+	var decoded interface{}
+	if err := codec.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// negotiateCodec picks the response codec: a "?format=" query override
+// takes precedence, otherwise the Accept header is matched against
+// encoding.Default, falling back to JSON.
+func negotiateCodec(r *http.Request) encoding.Codec {
+	if format := r.URL.Query().Get("format"); format != "" {
+		if codec := encoding.Default.ByFormat(format); codec != nil {
+			return codec
+		}
+	}
+	return encoding.Default.Negotiate(r.Header.Get("Accept"), encoding.JSONCodec{})
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the tunable HTTP server settings. It is sourced from
+// environment variables so operators can adjust timeouts without a code
+// change, mirroring how Sentry is configured in logger.InitSentryFromEnv.
+type Config struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	ShutdownTimeout   time.Duration
+
+	// TLSCertFile and TLSKeyFile enable TLS via ListenAndServeTLS when both
+	// are set.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// H2C enables HTTP/2 cleartext (h2c) so gRPC-style clients can talk to
+	// the echo API without TLS.
+	H2C bool
+
+	// RateLimitPerSecond and RateLimitBurst configure a per-IP token-bucket
+	// rate limiter. RateLimitPerSecond <= 0 disables rate limiting.
+	RateLimitPerSecond float64
+	RateLimitBurst     int
+
+	// CORSAllowedOrigins enables the CORS middleware when non-empty; "*"
+	// allows any origin.
+	CORSAllowedOrigins []string
+	CORSAllowedMethods []string
+	CORSAllowedHeaders []string
+
+	// GzipEnabled enables gzip response compression.
+	GzipEnabled bool
+
+	// MetricsUsername and MetricsPassword, when both set, guard /metrics
+	// with HTTP Basic Auth.
+	MetricsUsername string
+	MetricsPassword string
+}
+
+// DefaultConfig returns conservative timeouts for a server with no env
+// overrides.
+func DefaultConfig() Config {
+	return Config{
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      15 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		MaxHeaderBytes:    1 << 20, // 1MB
+		ShutdownTimeout:   10 * time.Second,
+	}
+}
+
+// ConfigFromEnv builds a Config from DefaultConfig, overridden by
+// READ_HEADER_TIMEOUT, READ_TIMEOUT, WRITE_TIMEOUT, IDLE_TIMEOUT and
+// SHUTDOWN_TIMEOUT (durations parsed by time.ParseDuration, e.g. "15s"),
+// MAX_HEADER_BYTES (bytes), TLS_CERT_FILE, TLS_KEY_FILE and H2C ("true").
+func ConfigFromEnv() Config {
+	config := DefaultConfig()
+
+	if v := durationFromEnv("READ_HEADER_TIMEOUT"); v > 0 {
+		config.ReadHeaderTimeout = v
+	}
+	if v := durationFromEnv("READ_TIMEOUT"); v > 0 {
+		config.ReadTimeout = v
+	}
+	if v := durationFromEnv("WRITE_TIMEOUT"); v > 0 {
+		config.WriteTimeout = v
+	}
+	if v := durationFromEnv("IDLE_TIMEOUT"); v > 0 {
+		config.IdleTimeout = v
+	}
+	if v := durationFromEnv("SHUTDOWN_TIMEOUT"); v > 0 {
+		config.ShutdownTimeout = v
+	}
+	if v := os.Getenv("MAX_HEADER_BYTES"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			config.MaxHeaderBytes = parsed
+		}
+	}
+
+	config.TLSCertFile = os.Getenv("TLS_CERT_FILE")
+	config.TLSKeyFile = os.Getenv("TLS_KEY_FILE")
+	config.H2C = os.Getenv("H2C") == "true"
+
+	if v := os.Getenv("RATE_LIMIT_PER_SECOND"); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			config.RateLimitPerSecond = parsed
+		}
+	}
+	config.RateLimitBurst = 1
+	if v := os.Getenv("RATE_LIMIT_BURST"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			config.RateLimitBurst = parsed
+		}
+	}
+
+	config.CORSAllowedOrigins = csvFromEnv("CORS_ALLOWED_ORIGINS")
+	config.CORSAllowedMethods = csvFromEnv("CORS_ALLOWED_METHODS")
+	config.CORSAllowedHeaders = csvFromEnv("CORS_ALLOWED_HEADERS")
+
+	config.GzipEnabled = os.Getenv("GZIP_ENABLED") == "true"
+
+	config.MetricsUsername = os.Getenv("METRICS_USERNAME")
+	config.MetricsPassword = os.Getenv("METRICS_PASSWORD")
+
+	return config
+}
+
+// csvFromEnv splits a comma-separated env var into a trimmed, non-empty
+// slice, or nil if the var is unset.
+func csvFromEnv(key string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+
+	var values []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+func durationFromEnv(key string) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0
+	}
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/secnex/bin-api/logger"
+)
+
+// Status responds with the HTTP status code given in the {code} path
+// variable, httpbin-style (e.g. GET /status/418).
+func (s *Server) Status(w http.ResponseWriter, r *http.Request) {
+	code, err := strconv.Atoi(r.PathValue("code"))
+	if err != nil || code < 100 || code > 599 {
+		http.Error(w, "invalid status code", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": code})
+}
+
+// Delay waits for the number of seconds given in the {seconds} path
+// variable (capped at 10s) before echoing the request, httpbin-style.
+func (s *Server) Delay(w http.ResponseWriter, r *http.Request) {
+	seconds, err := strconv.Atoi(r.PathValue("seconds"))
+	if err != nil || seconds < 0 {
+		http.Error(w, "invalid delay", http.StatusBadRequest)
+		return
+	}
+	if seconds > 10 {
+		seconds = 10
+	}
+
+	select {
+	case <-time.After(time.Duration(seconds) * time.Second):
+	case <-r.Context().Done():
+		return
+	}
+
+	s.HandleRequest(w, r)
+}
+
+// Headers responds with the request's headers.
+func (s *Server) Headers(w http.ResponseWriter, r *http.Request) {
+	headers := make(map[string]interface{})
+	for key, values := range r.Header {
+		if len(values) == 1 {
+			headers[key] = values[0]
+		} else {
+			headers[key] = values
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"headers": headers})
+}
+
+// IP responds with the caller's remote address.
+func (s *Server) IP(w http.ResponseWriter, r *http.Request) {
+	origin := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		origin = host
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"origin": origin})
+}
+
+// UserAgent responds with the caller's User-Agent header.
+func (s *Server) UserAgent(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user-agent": r.UserAgent()})
+}
+
+// Cookies responds with the request's cookies.
+func (s *Server) Cookies(w http.ResponseWriter, r *http.Request) {
+	cookies := make(map[string]interface{})
+	for _, cookie := range r.Cookies() {
+		cookies[cookie.Name] = cookie.Value
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"cookies": cookies})
+}
+
+// Redirect follows {n} redirects down to /redirect/{n-1}, finally landing on
+// /get, httpbin-style.
+func (s *Server) Redirect(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil || n < 0 {
+		http.Error(w, "invalid redirect count", http.StatusBadRequest)
+		return
+	}
+
+	if n <= 1 {
+		http.Redirect(w, r, "/get", http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/redirect/%d", n-1), http.StatusFound)
+}
+
+// Base64 decodes the {value} path variable as standard base64 and writes the
+// decoded bytes back as the response body.
+func (s *Server) Base64(w http.ResponseWriter, r *http.Request) {
+	decoded, err := base64.StdEncoding.DecodeString(r.PathValue("value"))
+	if err != nil {
+		http.Error(w, "invalid base64 value", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(decoded)
+}
+
+// UUID responds with a random UUID (v4).
+func (s *Server) UUID(w http.ResponseWriter, r *http.Request) {
+	id, err := newUUID()
+	if err != nil {
+		logger.LogError(err, "Failed to generate UUID", map[string]string{"endpoint": "uuid"}, nil)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"uuid": id})
+}
+
+// Bytes responds with {n} random bytes (capped at 100KiB).
+func (s *Server) Bytes(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil || n < 0 {
+		http.Error(w, "invalid byte count", http.StatusBadRequest)
+		return
+	}
+	if n > 100*1024 {
+		n = 100 * 1024
+	}
+
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		logger.LogError(err, "Failed to generate random bytes", map[string]string{"endpoint": "bytes"}, nil)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf)
+}
+
+// Stream writes {n} newline-delimited JSON objects, flushing after each one,
+// httpbin-style.
+func (s *Server) Stream(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil || n < 0 {
+		http.Error(w, "invalid stream count", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	for i := 0; i < n; i++ {
+		if err := encoder.Encode(map[string]interface{}{"id": i}); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// newUUID generates a random RFC 4122 version 4 UUID.
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
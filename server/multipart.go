@@ -0,0 +1,74 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// maxMultipartMemory bounds how much of a multipart/form-data body is
+// buffered in memory before net/http spills parts to temp files.
+const maxMultipartMemory = 32 << 20 // 32MB
+
+// parseMultipart parses a multipart/form-data request into plain form
+// values plus metadata (filename, size, sha256) for each uploaded file.
+func parseMultipart(r *http.Request) (interface{}, error) {
+	if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+		return nil, err
+	}
+
+	form := make(map[string]interface{}, len(r.MultipartForm.Value))
+	for key, values := range r.MultipartForm.Value {
+		if len(values) == 1 {
+			form[key] = values[0]
+		} else {
+			form[key] = values
+		}
+	}
+
+	files := make(map[string]interface{}, len(r.MultipartForm.File))
+	for field, headers := range r.MultipartForm.File {
+		metas := make([]map[string]interface{}, 0, len(headers))
+		for _, header := range headers {
+			meta, err := fileMetadata(header)
+			if err != nil {
+				return nil, err
+			}
+			metas = append(metas, meta)
+		}
+		if len(metas) == 1 {
+			files[field] = metas[0]
+		} else {
+			files[field] = metas
+		}
+	}
+
+	return map[string]interface{}{
+		"form":  form,
+		"files": files,
+	}, nil
+}
+
+// fileMetadata reads header's file once to compute its size and sha256
+// digest, without holding the whole file in memory.
+func fileMetadata(header *multipart.FileHeader) (map[string]interface{}, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"filename": header.Filename,
+		"size":     size,
+		"sha256":   hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
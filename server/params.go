@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// patternContextKey is the context key Handle uses to record the
+// registered route pattern, so pathParams can recover it without relying
+// on http.Request.Pattern (which also carries the "METHOD " prefix that
+// Handle adds when registering with the mux).
+type patternContextKey struct{}
+
+// withPattern returns r with pattern attached to its context for pathParams
+// to read back later.
+func withPattern(r *http.Request, pattern string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), patternContextKey{}, pattern))
+}
+
+// pathParams extracts the named path variables from the Go 1.22+ ServeMux
+// pattern that Handle registered for r (e.g. "/status/{code}") into a plain
+// map, so handlers can surface them without knowing their own pattern.
+func pathParams(r *http.Request) map[string]interface{} {
+	params := map[string]interface{}{}
+
+	pattern, _ := r.Context().Value(patternContextKey{}).(string)
+	for _, segment := range strings.Split(pattern, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		name = strings.TrimSuffix(name, "...")
+		params[name] = r.PathValue(name)
+	}
+
+	return params
+}
@@ -1,25 +1,69 @@
 package server
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
-	"time"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/secnex/bin-api/logger"
+	"github.com/secnex/bin-api/metrics"
+	"github.com/secnex/bin-api/middleware"
 )
 
 type Server struct {
-	Host string
-	Port int
+	Host   string
+	Port   int
+	Config Config
+
+	mux         *http.ServeMux
+	httpServer  *http.Server
+	middlewares []middleware.Middleware
+}
+
+// Use appends mw to the middleware chain applied to every request, on top
+// of the built-in rate limiting/CORS/gzip middlewares Start configures from
+// Config. Middlewares run in the order they were added (the first added is
+// the outermost wrapper).
+func (s *Server) Use(mw ...middleware.Middleware) {
+	s.middlewares = append(s.middlewares, mw...)
+}
+
+// defaultMiddlewares builds the built-in middleware stack from Config.
+func (s *Server) defaultMiddlewares() []middleware.Middleware {
+	var mws []middleware.Middleware
+
+	if s.Config.RateLimitPerSecond > 0 {
+		mws = append(mws, middleware.PerIPRateLimit(s.Config.RateLimitPerSecond, s.Config.RateLimitBurst))
+	}
+	if len(s.Config.CORSAllowedOrigins) > 0 {
+		mws = append(mws, middleware.CORS(middleware.CORSConfig{
+			AllowedOrigins: s.Config.CORSAllowedOrigins,
+			AllowedMethods: s.Config.CORSAllowedMethods,
+			AllowedHeaders: s.Config.CORSAllowedHeaders,
+		}))
+	}
+	if s.Config.GzipEnabled {
+		mws = append(mws, middleware.Gzip)
+	}
+
+	return mws
 }
 
 func NewServer(host string, port int) *Server {
 	return &Server{
-		Host: host,
-		Port: port,
+		Host:   host,
+		Port:   port,
+		Config: ConfigFromEnv(),
+		mux:    http.NewServeMux(),
 	}
 }
 
@@ -32,40 +76,36 @@ func (s *Server) initSentry() {
 	logger.InitSentryFromEnv()
 }
 
+// Handle registers handler for method and pattern, e.g.
+// Handle("GET", "/widgets/{id}", h). Patterns follow the net/http 1.22+
+// ServeMux syntax, including "{name}" path variables. An empty method
+// matches any HTTP method, matching http.ServeMux.Handle's own behavior.
+func (s *Server) Handle(method, pattern string, handler http.HandlerFunc) {
+	routePattern := pattern
+	if method != "" {
+		pattern = method + " " + pattern
+	}
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		handler(w, withPattern(r, routePattern))
+	})
+}
+
 // Alle Anfragen, die an den Server gesendet werden, sollen als als JSON-Objekt verarbeitet werden und der Body, die Parameter und Headers sollen anschließend als JSON zurückgegeben werden.
 func (s *Server) HandleRequest(w http.ResponseWriter, r *http.Request) {
 	// Performance wird automatisch durch logger.LogHTTPRequest erfasst
 
 	response := make(map[string]interface{})
 
-	// Body verarbeiten, wenn vorhanden
-	if r.Body != nil {
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			// Log error to Sentry
-			logger.LogError(err, "Failed to read request body",
-				map[string]string{"endpoint": "handle_request"},
-				map[string]interface{}{"url": r.URL.String()})
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if len(body) > 0 {
-			var bodyData interface{}
-			if err := json.Unmarshal(body, &bodyData); err != nil {
-				// Log JSON parsing error to Sentry
-				logger.LogError(err, "Failed to parse JSON body",
-					map[string]string{"endpoint": "handle_request"},
-					map[string]interface{}{"body": string(body)})
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			response["body"] = bodyData
-		} else {
-			response["body"] = map[string]interface{}{}
-		}
-	} else {
-		response["body"] = map[string]interface{}{}
+	// Body gemäß Content-Type verarbeiten (JSON/YAML/msgpack/form/multipart)
+	body, err := decodeBody(r)
+	if err != nil {
+		logger.LogError(err, "Failed to decode request body",
+			map[string]string{"endpoint": "handle_request"},
+			map[string]interface{}{"url": r.URL.String(), "content_type": r.Header.Get("Content-Type")})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
+	response["body"] = body
 
 	// Header verarbeiten
 	headers := make(map[string]interface{})
@@ -90,12 +130,24 @@ func (s *Server) HandleRequest(w http.ResponseWriter, r *http.Request) {
 	response["queries"] = queries
 
 	// URL-Parameter verarbeiten
-	response["params"] = map[string]interface{}{}
+	response["params"] = pathParams(r)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	// Request-ID, gesetzt von logger.LogHTTPRequest
+	response["request_id"] = logger.RequestIDFromContext(r.Context())
 
-	json.NewEncoder(w).Encode(response)
+	// Antwortformat verhandeln (Accept-Header oder ?format=) und kodieren
+	codec := negotiateCodec(r)
+	encoded, err := codec.Marshal(response)
+	if err != nil {
+		logger.LogError(err, "Failed to encode response",
+			map[string]string{"endpoint": "handle_request", "content_type": codec.ContentType()}, nil)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", codec.ContentType())
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
 }
 
 func (s *Server) Healthz(w http.ResponseWriter, r *http.Request) {
@@ -103,25 +155,120 @@ func (s *Server) Healthz(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("OK"))
 }
 
+// registerRoutes wires up the built-in echo/bin endpoints and the
+// operational routes (/healthz, /metrics) on top of whatever the caller
+// already registered via Handle.
+func (s *Server) registerRoutes() {
+	s.mux.HandleFunc("/", s.HandleRequest)
+	s.mux.HandleFunc("/healthz", s.Healthz)
+
+	var metricsHandler http.Handler = metrics.Default().Handler()
+	if s.Config.MetricsUsername != "" && s.Config.MetricsPassword != "" {
+		metricsHandler = middleware.BasicAuth("metrics", s.Config.MetricsUsername, s.Config.MetricsPassword)(metricsHandler)
+	}
+	s.mux.Handle("/metrics", metricsHandler)
+
+	s.Handle("GET", "/get", s.HandleRequest)
+	s.Handle("POST", "/post", s.HandleRequest)
+	s.Handle("PUT", "/put", s.HandleRequest)
+	s.Handle("DELETE", "/delete", s.HandleRequest)
+	s.Handle("", "/status/{code}", s.Status)
+	s.Handle("", "/delay/{seconds}", s.Delay)
+	s.Handle("", "/headers", s.Headers)
+	s.Handle("", "/ip", s.IP)
+	s.Handle("", "/user-agent", s.UserAgent)
+	s.Handle("", "/cookies", s.Cookies)
+	s.Handle("", "/redirect/{n}", s.Redirect)
+	s.Handle("", "/base64/{value}", s.Base64)
+	s.Handle("", "/uuid", s.UUID)
+	s.Handle("", "/bytes/{n}", s.Bytes)
+	s.Handle("", "/stream/{n}", s.Stream)
+
+	// Collapse path variables (e.g. "/status/418") down to their registered
+	// pattern ("/status/{code}") so metrics label cardinality stays bounded.
+	metrics.Default().SetNormalizer(func(method, path string) string {
+		_, pattern := s.mux.Handler(&http.Request{Method: method, URL: &url.URL{Path: path}})
+		if pattern == "" {
+			return path
+		}
+		if idx := strings.IndexByte(pattern, ' '); idx != -1 {
+			return pattern[idx+1:]
+		}
+		return pattern
+	})
+}
+
+// Shutdown gracefully drains in-flight connections, per http.Server.Shutdown.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Shutdown(ctx)
+}
+
 func (s *Server) Start() {
 	// Initialize Sentry first
 	s.initSentry()
 
 	log.Printf("Starting server on %s", s.String())
 
-	// Setup graceful shutdown
-	defer func() {
-		logger.Flush(10 * time.Second)
+	s.registerRoutes()
+
+	var handler http.Handler = s.mux
+	if s.Config.H2C {
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+	allMiddlewares := append(append([]middleware.Middleware{}, s.middlewares...), s.defaultMiddlewares()...)
+	handler = middleware.Chain(handler, allMiddlewares...)
+	handler = logger.LogHTTPRequest(handler)
+
+	s.httpServer = &http.Server{
+		Addr:              s.String(),
+		Handler:           handler,
+		ReadHeaderTimeout: s.Config.ReadHeaderTimeout,
+		ReadTimeout:       s.Config.ReadTimeout,
+		WriteTimeout:      s.Config.WriteTimeout,
+		IdleTimeout:       s.Config.IdleTimeout,
+		MaxHeaderBytes:    s.Config.MaxHeaderBytes,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		var err error
+		if s.Config.TLSCertFile != "" && s.Config.TLSKeyFile != "" {
+			err = s.httpServer.ListenAndServeTLS(s.Config.TLSCertFile, s.Config.TLSKeyFile)
+		} else {
+			err = s.httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+		}
+		close(serveErr)
 	}()
 
-	router := http.NewServeMux()
-	router.HandleFunc("/", s.HandleRequest)
-	router.HandleFunc("/healthz", s.Healthz)
-	handler := logger.LogHTTPRequest(router)
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
-	if err := http.ListenAndServe(s.String(), handler); err != nil {
-		logger.LogError(err, "Server failed to start",
-			map[string]string{"host": s.Host, "port": fmt.Sprintf("%d", s.Port)},
-			nil)
+	select {
+	case err, ok := <-serveErr:
+		if ok && err != nil {
+			logger.LogError(err, "Server failed to start",
+				map[string]string{"host": s.Host, "port": fmt.Sprintf("%d", s.Port)},
+				nil)
+		}
+	case sig := <-stop:
+		log.Printf("Received %s, shutting down", sig)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.Config.ShutdownTimeout)
+		defer cancel()
+
+		if err := s.Shutdown(ctx); err != nil {
+			logger.LogError(err, "Graceful shutdown failed", nil, nil)
+		}
 	}
+
+	// Flush after the server has actually stopped serving, not on entry to
+	// Start - a deferred flush there used to run immediately on panic,
+	// dropping events queued during the request that caused it.
+	logger.Flush(s.Config.ShutdownTimeout)
 }